@@ -0,0 +1,61 @@
+// Package bigmath provides modular arithmetic primitives over math/big.Int,
+// mirroring the int-based helpers in the root package at sizes large enough
+// for real cryptographic use (hundreds to thousands of bits).
+package bigmath
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ModAdd performs modular addition: (a + b) mod m
+func ModAdd(a, b, m *big.Int) *big.Int {
+	result := new(big.Int).Add(a, b)
+	return result.Mod(result, m)
+}
+
+// ModSub performs modular subtraction: (a - b) mod m
+func ModSub(a, b, m *big.Int) *big.Int {
+	result := new(big.Int).Sub(a, b)
+	return result.Mod(result, m)
+}
+
+// ModMul performs modular multiplication: (a * b) mod m
+func ModMul(a, b, m *big.Int) *big.Int {
+	result := new(big.Int).Mul(a, b)
+	return result.Mod(result, m)
+}
+
+// ModExp performs modular exponentiation: base^exp mod m
+func ModExp(base, exp, m *big.Int) *big.Int {
+	return new(big.Int).Exp(base, exp, m)
+}
+
+// ExtendedGCD returns gcd, x, y where gcd = a*x + b*y
+func ExtendedGCD(a, b *big.Int) (*big.Int, *big.Int, *big.Int) {
+	if b.Sign() == 0 {
+		return new(big.Int).Set(a), big.NewInt(1), big.NewInt(0)
+	}
+	q, r := new(big.Int), new(big.Int)
+	q.DivMod(a, b, r)
+	gcd, x1, y1 := ExtendedGCD(b, r)
+	x := y1
+	y := new(big.Int).Sub(x1, new(big.Int).Mul(q, y1))
+	return gcd, x, y
+}
+
+// GCD calculates the greatest common divisor using the extended Euclidean algorithm
+func GCD(a, b *big.Int) *big.Int {
+	gcd, _, _ := ExtendedGCD(a, b)
+	return new(big.Int).Abs(gcd)
+}
+
+// ModInverse finds the modular multiplicative inverse of a modulo m
+func ModInverse(a, m *big.Int) (*big.Int, error) {
+	gcd, x, _ := ExtendedGCD(a, m)
+	if gcd.CmpAbs(big.NewInt(1)) != 0 {
+		return nil, fmt.Errorf("modular inverse does not exist")
+	}
+	result := new(big.Int).Mod(x, m)
+	return result, nil
+}
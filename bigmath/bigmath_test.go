@@ -0,0 +1,78 @@
+package bigmath
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestModArithmetic(t *testing.T) {
+	a, b, m := big64(7), big64(5), big64(11)
+
+	if got := ModAdd(a, b, m); got.Cmp(big64(1)) != 0 {
+		t.Errorf("ModAdd(7, 5, 11) = %s, want 1", got)
+	}
+	if got := ModSub(a, b, m); got.Cmp(big64(2)) != 0 {
+		t.Errorf("ModSub(7, 5, 11) = %s, want 2", got)
+	}
+	if got := ModMul(a, b, m); got.Cmp(big64(2)) != 0 {
+		t.Errorf("ModMul(7, 5, 11) = %s, want 2", got)
+	}
+	if got := ModExp(a, b, m); got.Cmp(big64(10)) != 0 {
+		t.Errorf("ModExp(7, 5, 11) = %s, want 10", got)
+	}
+}
+
+func TestModSubNegativeWrapsPositive(t *testing.T) {
+	got := ModSub(big64(2), big64(5), big64(11))
+	if got.Sign() < 0 || got.Cmp(big64(8)) != 0 {
+		t.Errorf("ModSub(2, 5, 11) = %s, want 8", got)
+	}
+}
+
+func TestGCD(t *testing.T) {
+	tests := []struct{ a, b, want int64 }{
+		{48, 18, 6},
+		{17, 5, 1},
+		{0, 5, 5},
+		{5, 0, 5},
+	}
+	for _, tc := range tests {
+		if got := GCD(big64(tc.a), big64(tc.b)); got.Cmp(big64(tc.want)) != 0 {
+			t.Errorf("GCD(%d, %d) = %s, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestExtendedGCDBezoutIdentity(t *testing.T) {
+	a, b := big64(240), big64(46)
+	g, x, y := ExtendedGCD(a, b)
+
+	check := new(big.Int).Add(new(big.Int).Mul(a, x), new(big.Int).Mul(b, y))
+	if check.Cmp(g) != 0 {
+		t.Errorf("a*x+b*y = %s, want gcd %s", check, g)
+	}
+	if g.Cmp(big64(2)) != 0 {
+		t.Errorf("gcd(240, 46) = %s, want 2", g)
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	inv, err := ModInverse(big64(3), big64(11))
+	if err != nil {
+		t.Fatalf("ModInverse(3, 11) returned error: %v", err)
+	}
+	if inv.Cmp(big64(4)) != 0 {
+		t.Errorf("ModInverse(3, 11) = %s, want 4", inv)
+	}
+
+	product := new(big.Int).Mod(new(big.Int).Mul(big64(3), inv), big64(11))
+	if product.Cmp(big64(1)) != 0 {
+		t.Errorf("3 * inverse mod 11 = %s, want 1", product)
+	}
+}
+
+func TestModInverseNoInverse(t *testing.T) {
+	if _, err := ModInverse(big64(4), big64(8)); err == nil {
+		t.Error("ModInverse(4, 8) should have returned an error (gcd != 1)")
+	}
+}
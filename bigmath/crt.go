@@ -0,0 +1,80 @@
+package bigmath
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// CRTBuilder incrementally solves a system of congruences x ≡ r (mod m) by
+// merging one congruence at a time, so large systems can be solved without
+// holding every residue/modulus pair in memory at once. Moduli need not be
+// coprime.
+type CRTBuilder struct {
+	r, m        *big.Int
+	initialized bool
+}
+
+// NewCRTBuilder returns an empty CRTBuilder.
+func NewCRTBuilder() *CRTBuilder {
+	return &CRTBuilder{}
+}
+
+// Add merges the congruence x ≡ r (mod m) into the system, returning an
+// error if it is inconsistent with the congruences added so far.
+func (b *CRTBuilder) Add(r, m *big.Int) error {
+	if !b.initialized {
+		b.r = new(big.Int).Mod(r, m)
+		b.m = new(big.Int).Set(m)
+		b.initialized = true
+		return nil
+	}
+
+	g, p, _ := ExtendedGCD(b.m, m)
+
+	diff := new(big.Int).Sub(r, b.r)
+	rem := new(big.Int).Mod(diff, g)
+	if rem.Sign() != 0 {
+		return fmt.Errorf("inconsistent system: no solution modulo gcd(%s, %s)", b.m, m)
+	}
+
+	lcm := new(big.Int).Div(b.m, g)
+	lcm.Mul(lcm, m)
+
+	k := new(big.Int).Div(diff, g)
+	newR := new(big.Int).Add(b.r, new(big.Int).Mul(b.m, new(big.Int).Mul(k, p)))
+	newR.Mod(newR, lcm)
+
+	b.r, b.m = newR, lcm
+	return nil
+}
+
+// Solution returns the combined remainder and modulus of every congruence
+// added so far.
+func (b *CRTBuilder) Solution() (r, m *big.Int) {
+	if !b.initialized {
+		return big.NewInt(0), big.NewInt(1)
+	}
+	return b.r, b.m
+}
+
+// CRT solves a system of congruences x ≡ residues[i] (mod moduli[i]),
+// returning the combined remainder and modulus, or an error if the system
+// is inconsistent. Moduli need not be coprime.
+func CRT(residues, moduli []*big.Int) (r, m *big.Int, err error) {
+	if len(residues) != len(moduli) {
+		return nil, nil, fmt.Errorf("residues and moduli must have same length")
+	}
+	if len(residues) == 0 {
+		return nil, nil, fmt.Errorf("at least one congruence is required")
+	}
+
+	builder := NewCRTBuilder()
+	for i := range residues {
+		if err := builder.Add(residues[i], moduli[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	r, m = builder.Solution()
+	return r, m, nil
+}
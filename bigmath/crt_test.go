@@ -0,0 +1,64 @@
+package bigmath
+
+import (
+	"math/big"
+	"testing"
+)
+
+func big64(v int64) *big.Int { return big.NewInt(v) }
+
+func TestCRTCoprime(t *testing.T) {
+	// x ≡ 2 (mod 3), x ≡ 3 (mod 5), x ≡ 2 (mod 7) -> x ≡ 23 (mod 105)
+	r, m, err := CRT(
+		[]*big.Int{big64(2), big64(3), big64(2)},
+		[]*big.Int{big64(3), big64(5), big64(7)},
+	)
+	if err != nil {
+		t.Fatalf("CRT returned error: %v", err)
+	}
+	if m.Cmp(big64(105)) != 0 {
+		t.Fatalf("modulus = %s, want 105", m)
+	}
+	if r.Cmp(big64(23)) != 0 {
+		t.Fatalf("remainder = %s, want 23", r)
+	}
+}
+
+func TestCRTBuilderNonCoprime(t *testing.T) {
+	// x ≡ 1 (mod 6), x ≡ 4 (mod 9) -> x ≡ 13 (mod 18)
+	b := NewCRTBuilder()
+	if err := b.Add(big64(1), big64(6)); err != nil {
+		t.Fatalf("Add(1, 6) returned error: %v", err)
+	}
+	if err := b.Add(big64(4), big64(9)); err != nil {
+		t.Fatalf("Add(4, 9) returned error: %v", err)
+	}
+
+	r, m := b.Solution()
+	if m.Cmp(big64(18)) != 0 {
+		t.Fatalf("modulus = %s, want 18", m)
+	}
+	if r.Cmp(big64(13)) != 0 {
+		t.Fatalf("remainder = %s, want 13", r)
+	}
+}
+
+func TestCRTNonCoprimeInconsistent(t *testing.T) {
+	// x ≡ 1 (mod 6), x ≡ 2 (mod 9): both require a residue mod gcd(6,9)=3,
+	// but 1 mod 3 = 1 and 2 mod 3 = 2, so the system has no solution.
+	_, _, err := CRT(
+		[]*big.Int{big64(1), big64(2)},
+		[]*big.Int{big64(6), big64(9)},
+	)
+	if err == nil {
+		t.Fatal("expected error for inconsistent system, got nil")
+	}
+}
+
+func TestCRTBuilderEmpty(t *testing.T) {
+	b := NewCRTBuilder()
+	r, m := b.Solution()
+	if r.Cmp(big64(0)) != 0 || m.Cmp(big64(1)) != 0 {
+		t.Fatalf("empty builder solution = (%s, %s), want (0, 1)", r, m)
+	}
+}
@@ -0,0 +1,275 @@
+package bigmath
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// smallPrimes lists primes below 2000 used to sieve out obvious composites
+// before running the more expensive probabilistic tests.
+var smallPrimes = sieveSmallPrimes(2000)
+
+func sieveSmallPrimes(limit int) []int64 {
+	composite := make([]bool, limit+1)
+	primes := []int64{}
+	for i := 2; i <= limit; i++ {
+		if composite[i] {
+			continue
+		}
+		primes = append(primes, int64(i))
+		for j := i * i; j <= limit; j += i {
+			composite[j] = true
+		}
+	}
+	return primes
+}
+
+// IsProbablePrime reports whether n is prime using trial division against
+// small primes followed by a Miller-Rabin test with the given number of
+// witness rounds, each drawn from crypto/rand.
+func IsProbablePrime(n *big.Int, rounds int) bool {
+	if n.Sign() <= 0 || n.Cmp(big.NewInt(1)) == 0 {
+		return false
+	}
+
+	for _, p := range smallPrimes {
+		bp := big.NewInt(p)
+		if n.Cmp(bp) == 0 {
+			return true
+		}
+		if new(big.Int).Mod(n, bp).Sign() == 0 {
+			return false
+		}
+	}
+
+	return millerRabin(n, rounds)
+}
+
+// millerRabin runs the Miller-Rabin probabilistic primality test on odd n
+// with the given number of randomly chosen witnesses.
+func millerRabin(n *big.Int, rounds int) bool {
+	if n.Bit(0) == 0 {
+		return n.Cmp(big.NewInt(2)) == 0
+	}
+
+	nMinusOne := new(big.Int).Sub(n, big.NewInt(1))
+
+	// Write n-1 = 2^s * d with d odd.
+	d := new(big.Int).Set(nMinusOne)
+	s := 0
+	for d.Bit(0) == 0 {
+		d.Rsh(d, 1)
+		s++
+	}
+
+	if n.Cmp(big.NewInt(3)) <= 0 {
+		return n.Cmp(big.NewInt(1)) > 0
+	}
+
+	nMinusThree := new(big.Int).Sub(n, big.NewInt(3))
+	one := big.NewInt(1)
+
+	for i := 0; i < rounds; i++ {
+		a, err := rand.Int(rand.Reader, nMinusThree)
+		if err != nil {
+			return false
+		}
+		a.Add(a, big.NewInt(2)) // a in [2, n-2]
+
+		x := new(big.Int).Exp(a, d, n)
+		if x.Cmp(one) == 0 || x.Cmp(nMinusOne) == 0 {
+			continue
+		}
+
+		witness := true
+		for r := 0; r < s-1; r++ {
+			x.Mul(x, x)
+			x.Mod(x, n)
+			if x.Cmp(nMinusOne) == 0 {
+				witness = false
+				break
+			}
+		}
+		if witness {
+			return false
+		}
+	}
+	return true
+}
+
+// jacobi computes the Jacobi symbol (a/n) for odd n > 0.
+func jacobi(a, n *big.Int) int {
+	a = new(big.Int).Mod(a, n)
+	n = new(big.Int).Set(n)
+	result := 1
+
+	one := big.NewInt(1)
+	two := big.NewInt(2)
+	three := big.NewInt(3)
+	four := big.NewInt(4)
+	five := big.NewInt(5)
+	eight := big.NewInt(8)
+
+	for a.Sign() != 0 {
+		for new(big.Int).Mod(a, two).Sign() == 0 {
+			a.Div(a, two)
+			r := new(big.Int).Mod(n, eight)
+			if r.Cmp(three) == 0 || r.Cmp(five) == 0 {
+				result = -result
+			}
+		}
+		a, n = n, a
+		if new(big.Int).Mod(a, four).Cmp(three) == 0 && new(big.Int).Mod(n, four).Cmp(three) == 0 {
+			result = -result
+		}
+		a.Mod(a, n)
+	}
+	if n.Cmp(one) == 0 {
+		return result
+	}
+	return 0
+}
+
+// lucasUV computes the Lucas sequence terms U_k mod n and V_k mod n for
+// parameters P, Q (with discriminant D = P^2 - 4Q) via index doubling.
+func lucasUV(k, p, q, d, n *big.Int) (*big.Int, *big.Int) {
+	inv2, _ := ModInverse(big.NewInt(2), n)
+
+	u := big.NewInt(0)
+	v := big.NewInt(2)
+	qk := big.NewInt(1)
+
+	bits := k.BitLen()
+	for i := bits - 1; i >= 0; i-- {
+		// Double: U_2m = U_m*V_m, V_2m = V_m^2 - 2*Q^m
+		u2 := new(big.Int).Mod(new(big.Int).Mul(u, v), n)
+		v2 := new(big.Int).Mul(v, v)
+		v2.Sub(v2, new(big.Int).Lsh(qk, 1))
+		v2.Mod(v2, n)
+		qk.Mod(new(big.Int).Mul(qk, qk), n)
+
+		u, v = u2, v2
+
+		if k.Bit(i) == 1 {
+			// Step forward one: U_{m+1} = (P*U_m+V_m)/2, V_{m+1} = (D*U_m+P*V_m)/2
+			nu := new(big.Int).Add(new(big.Int).Mul(p, u), v)
+			nu.Mul(nu, inv2)
+			nu.Mod(nu, n)
+
+			nv := new(big.Int).Add(new(big.Int).Mul(d, u), new(big.Int).Mul(p, v))
+			nv.Mul(nv, inv2)
+			nv.Mod(nv, n)
+
+			u, v = nu, nv
+			qk.Mod(new(big.Int).Mul(qk, q), n)
+		}
+	}
+	return u, v
+}
+
+// lucasTest runs the strong Lucas probable prime test on odd n using
+// Selfridge parameters: P=1, and D as the first value in 5,-7,9,-11,...
+// whose Jacobi symbol with n is -1.
+func lucasTest(n *big.Int) bool {
+	d := int64(5)
+	var D *big.Int
+	for {
+		D = big.NewInt(d)
+		j := jacobi(D, n)
+		if j == -1 {
+			break
+		}
+		if j == 0 {
+			absD := new(big.Int).Abs(D)
+			if absD.Cmp(n) != 0 {
+				return false
+			}
+		}
+		if d > 0 {
+			d = -(d + 2)
+		} else {
+			d = -d + 2
+		}
+	}
+
+	p := big.NewInt(1)
+	q := new(big.Int).Sub(big.NewInt(1), D)
+	q.Div(q, big.NewInt(4))
+
+	nPlusOne := new(big.Int).Add(n, big.NewInt(1))
+	delta := new(big.Int).Set(nPlusOne)
+	s := 0
+	for delta.Bit(0) == 0 {
+		delta.Rsh(delta, 1)
+		s++
+	}
+
+	u, v := lucasUV(delta, p, q, D, n)
+	if u.Sign() == 0 || v.Sign() == 0 {
+		return true
+	}
+
+	// Advance V_m to V_2m, V_4m, ... via V_2m = V_m^2 - 2*Q^m, tracking the
+	// running power Q^m alongside it (Q^2m = (Q^m)^2).
+	qPow := new(big.Int).Exp(q, delta, n)
+	for r := 0; r < s-1; r++ {
+		v.Mul(v, v)
+		v.Sub(v, new(big.Int).Lsh(qPow, 1))
+		v.Mod(v, n)
+		qPow.Mul(qPow, qPow)
+		qPow.Mod(qPow, n)
+		if v.Sign() == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBailliePSWPrime reports whether n passes a Baillie-PSW primality test:
+// a single base-2 Miller-Rabin round followed by a strong Lucas test. No
+// composite number is known to pass both.
+func IsBailliePSWPrime(n *big.Int) bool {
+	if n.Sign() <= 0 || n.Cmp(big.NewInt(1)) == 0 {
+		return false
+	}
+	if n.Cmp(big.NewInt(2)) == 0 {
+		return true
+	}
+	if n.Bit(0) == 0 {
+		return false
+	}
+	// Strong probable-prime test to base 2: with n-1 = 2^s * d, d odd, n
+	// passes if 2^d ≡ 1 or 2^(d*2^r) ≡ -1 (mod n) for some 0 <= r < s.
+	nMinusOne := new(big.Int).Sub(n, big.NewInt(1))
+	s := nMinusOne.TrailingZeroBits()
+	x := new(big.Int).Exp(big.NewInt(2), new(big.Int).Rsh(nMinusOne, s), n)
+	sprp := x.Cmp(big.NewInt(1)) == 0
+	for r := uint(0); r < s && !sprp; r++ {
+		sprp = x.Cmp(nMinusOne) == 0
+		x.Mul(x, x).Mod(x, n)
+	}
+	if !sprp {
+		return false
+	}
+	return lucasTest(n)
+}
+
+// GeneratePrime samples random odd candidates of the requested bit length
+// from rnd until one passes IsProbablePrime, returning an error if bits is
+// too small.
+func GeneratePrime(rnd io.Reader, bits int) (*big.Int, error) {
+	if bits < 2 {
+		return nil, fmt.Errorf("bits must be at least 2")
+	}
+	for {
+		candidate, err := rand.Prime(rnd, bits)
+		if err != nil {
+			return nil, err
+		}
+		if IsProbablePrime(candidate, 40) {
+			return candidate, nil
+		}
+	}
+}
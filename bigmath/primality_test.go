@@ -0,0 +1,66 @@
+package bigmath
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+var knownPrimes = []int64{
+	2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31,
+	97, 101, 7919, 104729,
+}
+
+var knownComposites = []int64{
+	0, 1, 4, 6, 8, 9, 15, 21, 25, 33, 49, 91, 561, 41041,
+}
+
+func TestIsProbablePrimeKnownValues(t *testing.T) {
+	for _, p := range knownPrimes {
+		if !IsProbablePrime(big.NewInt(p), 20) {
+			t.Errorf("IsProbablePrime(%d) = false, want true", p)
+		}
+	}
+	for _, c := range knownComposites {
+		if IsProbablePrime(big.NewInt(c), 20) {
+			t.Errorf("IsProbablePrime(%d) = true, want false", c)
+		}
+	}
+}
+
+func TestIsBailliePSWPrimeKnownValues(t *testing.T) {
+	for _, p := range knownPrimes {
+		if !IsBailliePSWPrime(big.NewInt(p)) {
+			t.Errorf("IsBailliePSWPrime(%d) = false, want true", p)
+		}
+	}
+	for _, c := range knownComposites {
+		if IsBailliePSWPrime(big.NewInt(c)) {
+			t.Errorf("IsBailliePSWPrime(%d) = true, want false", c)
+		}
+	}
+}
+
+func TestIsBailliePSWPrimeSmallOddNoPanic(t *testing.T) {
+	// n == 3 used to drive rand.Int with a non-positive bound and panic.
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("IsBailliePSWPrime(3) panicked: %v", r)
+		}
+	}()
+	if !IsBailliePSWPrime(big.NewInt(3)) {
+		t.Error("IsBailliePSWPrime(3) = false, want true")
+	}
+}
+
+func TestGeneratePrime(t *testing.T) {
+	for _, bits := range []int{8, 16, 64} {
+		p, err := GeneratePrime(rand.Reader, bits)
+		if err != nil {
+			t.Fatalf("GeneratePrime(%d) returned error: %v", bits, err)
+		}
+		if !IsProbablePrime(p, 20) {
+			t.Errorf("GeneratePrime(%d) = %s, not prime", bits, p)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package rsa
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/GoodbyeKittyy/modular-arithmetic-clock/bigmath"
+)
+
+// CRTValue holds the precomputed CRT coefficients for one prime beyond the
+// first two in a multi-prime key, following the same layout as
+// crypto/rsa.CRTValue.
+type CRTValue struct {
+	Exp   *big.Int // D mod (prime-1)
+	Coeff *big.Int // R*Coeff ≡ 1 mod prime
+	R     *big.Int // product of primes before this one
+}
+
+// GenerateMultiPrimeKey generates a multi-prime RSA key pair whose modulus
+// is the product of nprimes distinct primes totaling the requested bit
+// size, following the scheme used by crypto/rsa.GenerateMultiPrimeKey.
+func GenerateMultiPrimeKey(rnd io.Reader, nprimes, bits int) (*PrivateKey, error) {
+	if nprimes < 2 {
+		return nil, errors.New("rsa: GenerateMultiPrimeKey: nprimes must be >= 2")
+	}
+	if bits/nprimes < 32 {
+		return nil, errors.New("rsa: GenerateMultiPrimeKey: bits too small for nprimes")
+	}
+
+	e := big.NewInt(defaultE)
+	primeBits := bits / nprimes
+
+	for {
+		primes := make([]*big.Int, nprimes)
+		n := big.NewInt(1)
+		lambda := big.NewInt(1)
+		distinct := true
+
+		todo := bits
+		for i := 0; i < nprimes; i++ {
+			pb := primeBits
+			if i == nprimes-1 {
+				pb = todo
+			}
+			p, err := bigmath.GeneratePrime(rnd, pb)
+			if err != nil {
+				return nil, err
+			}
+			primes[i] = p
+			todo -= pb
+
+			for j := 0; j < i; j++ {
+				if primes[j].Cmp(p) == 0 {
+					distinct = false
+				}
+			}
+
+			n.Mul(n, p)
+			pMinusOne := new(big.Int).Sub(p, big.NewInt(1))
+			lambda = lcm(lambda, pMinusOne)
+		}
+		if !distinct {
+			continue
+		}
+		if n.BitLen() != bits {
+			continue
+		}
+		if bigmath.GCD(e, lambda).Cmp(big.NewInt(1)) != 0 {
+			continue
+		}
+
+		d, err := bigmath.ModInverse(e, lambda)
+		if err != nil {
+			continue
+		}
+
+		priv := &PrivateKey{
+			PublicKey: PublicKey{N: n, E: defaultE},
+			D:         d,
+			P:         primes[0],
+			Q:         primes[1],
+			Primes:    primes,
+		}
+
+		p0MinusOne := new(big.Int).Sub(primes[0], big.NewInt(1))
+		q0MinusOne := new(big.Int).Sub(primes[1], big.NewInt(1))
+		priv.Precomputed.Dp = new(big.Int).Mod(d, p0MinusOne)
+		priv.Precomputed.Dq = new(big.Int).Mod(d, q0MinusOne)
+		qinv, err := bigmath.ModInverse(primes[1], primes[0])
+		if err != nil {
+			continue
+		}
+		priv.Precomputed.Qinv = qinv
+
+		priv.Precomputed.CRTValues = make([]CRTValue, nprimes-2)
+		r := new(big.Int).Mul(primes[0], primes[1])
+		for i := 2; i < nprimes; i++ {
+			prime := primes[i]
+			exp := new(big.Int).Mod(d, new(big.Int).Sub(prime, big.NewInt(1)))
+			coeff, err := bigmath.ModInverse(r, prime)
+			if err != nil {
+				return nil, err
+			}
+			priv.Precomputed.CRTValues[i-2] = CRTValue{
+				Exp:   exp,
+				Coeff: coeff,
+				R:     new(big.Int).Set(r),
+			}
+			r.Mul(r, prime)
+		}
+
+		return priv, nil
+	}
+}
+
+// lcm returns the least common multiple of a and b.
+func lcm(a, b *big.Int) *big.Int {
+	g := bigmath.GCD(a, b)
+	result := new(big.Int).Div(a, g)
+	return result.Mul(result, b)
+}
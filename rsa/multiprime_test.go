@@ -0,0 +1,39 @@
+package rsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestGenerateMultiPrimeKeyRoundTrip(t *testing.T) {
+	priv, err := GenerateMultiPrimeKey(rand.Reader, 3, 768)
+	if err != nil {
+		t.Fatalf("GenerateMultiPrimeKey returned error: %v", err)
+	}
+	if len(priv.Primes) != 3 {
+		t.Fatalf("len(Primes) = %d, want 3", len(priv.Primes))
+	}
+	if len(priv.Precomputed.CRTValues) != 1 {
+		t.Fatalf("len(CRTValues) = %d, want 1", len(priv.Precomputed.CRTValues))
+	}
+
+	msg := []byte("HELLOWORLD")
+	ct, err := EncryptPKCS1v15(rand.Reader, &priv.PublicKey, msg)
+	if err != nil {
+		t.Fatalf("EncryptPKCS1v15 returned error: %v", err)
+	}
+	pt, err := DecryptPKCS1v15(priv, ct)
+	if err != nil {
+		t.Fatalf("DecryptPKCS1v15 returned error: %v", err)
+	}
+	if !bytes.Equal(pt, msg) {
+		t.Fatalf("round trip = %q, want %q", pt, msg)
+	}
+}
+
+func TestGenerateMultiPrimeKeyRejectsTooFewPrimes(t *testing.T) {
+	if _, err := GenerateMultiPrimeKey(rand.Reader, 1, 512); err == nil {
+		t.Fatal("GenerateMultiPrimeKey(nprimes=1) should have returned an error")
+	}
+}
@@ -0,0 +1,355 @@
+// Package rsa implements RSA key generation and encryption over byte
+// messages using math/big, building on the primitives in bigmath. Unlike
+// the toy int-based RSA in the root package, keys here can be generated at
+// real-world sizes (1024 bits and up) and private-key operations go through
+// CRT.
+package rsa
+
+import (
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/GoodbyeKittyy/modular-arithmetic-clock/bigmath"
+)
+
+// PublicKey is an RSA public key.
+type PublicKey struct {
+	N *big.Int // modulus
+	E int      // public exponent
+}
+
+// Precomputed holds CRT values that speed up private-key operations: Dp, Dq
+// and Qinv for the first two primes, and CRTValues for any further primes.
+type Precomputed struct {
+	Dp, Dq, Qinv *big.Int
+	CRTValues    []CRTValue // for primes beyond the first two
+}
+
+// PrivateKey is an RSA private key, including the precomputed CRT values
+// needed to decrypt without ever exponentiating mod the full modulus N.
+type PrivateKey struct {
+	PublicKey
+	D           *big.Int
+	P, Q        *big.Int
+	Primes      []*big.Int
+	Precomputed Precomputed
+}
+
+// defaultE is the standard public exponent used by GenerateKey.
+const defaultE = 65537
+
+// GenerateKey generates a two-prime RSA key pair of the given total bit
+// size, using a P of bits/2 bits and a Q of bits-bits/2 bits.
+func GenerateKey(bits int, rnd io.Reader) (*PrivateKey, error) {
+	if bits < 64 {
+		return nil, errors.New("rsa: key too small")
+	}
+
+	e := big.NewInt(defaultE)
+
+	for {
+		p, err := bigmath.GeneratePrime(rnd, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := bigmath.GeneratePrime(rnd, bits-bits/2)
+		if err != nil {
+			return nil, err
+		}
+		if p.Cmp(q) == 0 {
+			continue
+		}
+
+		pMinusOne := new(big.Int).Sub(p, big.NewInt(1))
+		qMinusOne := new(big.Int).Sub(q, big.NewInt(1))
+		lambda := carmichael(pMinusOne, qMinusOne)
+
+		if bigmath.GCD(e, lambda).Cmp(big.NewInt(1)) != 0 {
+			continue
+		}
+
+		d, err := bigmath.ModInverse(e, lambda)
+		if err != nil {
+			continue
+		}
+
+		n := new(big.Int).Mul(p, q)
+
+		dp := new(big.Int).Mod(d, pMinusOne)
+		dq := new(big.Int).Mod(d, qMinusOne)
+		qinv, err := bigmath.ModInverse(q, p)
+		if err != nil {
+			continue
+		}
+
+		return &PrivateKey{
+			PublicKey: PublicKey{N: n, E: defaultE},
+			D:         d,
+			P:         p,
+			Q:         q,
+			Primes:    []*big.Int{p, q},
+			Precomputed: Precomputed{
+				Dp:   dp,
+				Dq:   dq,
+				Qinv: qinv,
+			},
+		}, nil
+	}
+}
+
+// carmichael computes lcm(pMinusOne, qMinusOne), Carmichael's function
+// λ(n) for n = p*q.
+func carmichael(pMinusOne, qMinusOne *big.Int) *big.Int {
+	g := bigmath.GCD(pMinusOne, qMinusOne)
+	lcm := new(big.Int).Div(pMinusOne, g)
+	return lcm.Mul(lcm, qMinusOne)
+}
+
+// decryptCRT performs the private-key operation m = c^D mod N. For
+// two-prime keys this is the standard shortcut: m1 = c^Dp mod P,
+// m2 = c^Dq mod Q, h = Qinv*(m1-m2) mod P, m = m2 + h*Q. For keys with
+// more than two primes, each remaining prime is folded in via CRT
+// lifting: m = m_prev + R*(Coeff*(m_i - m_prev) mod p_i).
+func decryptCRT(priv *PrivateKey, c *big.Int) *big.Int {
+	m1 := new(big.Int).Exp(c, priv.Precomputed.Dp, priv.P)
+	m2 := new(big.Int).Exp(c, priv.Precomputed.Dq, priv.Q)
+
+	h := new(big.Int).Sub(m1, m2)
+	h.Mul(h, priv.Precomputed.Qinv)
+	h.Mod(h, priv.P)
+
+	m := new(big.Int).Mul(h, priv.Q)
+	m.Add(m, m2)
+
+	for i, values := range priv.Precomputed.CRTValues {
+		prime := priv.Primes[i+2]
+		mi := new(big.Int).Exp(c, values.Exp, prime)
+
+		hi := new(big.Int).Sub(mi, m)
+		hi.Mul(hi, values.Coeff)
+		hi.Mod(hi, prime)
+
+		lifted := new(big.Int).Mul(hi, values.R)
+		m.Add(m, lifted)
+	}
+
+	return m
+}
+
+// i2osp encodes x as a big-endian byte slice of exactly size bytes,
+// returning an error if x does not fit.
+func i2osp(x *big.Int, size int) ([]byte, error) {
+	b := x.Bytes()
+	if len(b) > size {
+		return nil, errors.New("rsa: integer too large to encode")
+	}
+	out := make([]byte, size)
+	copy(out[size-len(b):], b)
+	return out, nil
+}
+
+// EncryptPKCS1v15 encrypts msg with PKCS#1 v1.5 padding under the public key.
+func EncryptPKCS1v15(rnd io.Reader, pub *PublicKey, msg []byte) ([]byte, error) {
+	k := (pub.N.BitLen() + 7) / 8
+	if len(msg) > k-11 {
+		return nil, errors.New("rsa: message too long for modulus")
+	}
+
+	em := make([]byte, k)
+	em[0] = 0x00
+	em[1] = 0x02
+
+	padLen := k - len(msg) - 3
+	padding := make([]byte, padLen)
+	if err := nonZeroRandomBytes(rnd, padding); err != nil {
+		return nil, err
+	}
+	copy(em[2:], padding)
+	em[2+padLen] = 0x00
+	copy(em[3+padLen:], msg)
+
+	m := new(big.Int).SetBytes(em)
+	c := new(big.Int).Exp(m, big.NewInt(int64(pub.E)), pub.N)
+	return i2osp(c, k)
+}
+
+// nonZeroRandomBytes fills buf with random non-zero bytes, as required by
+// PKCS#1 v1.5 padding.
+func nonZeroRandomBytes(rnd io.Reader, buf []byte) error {
+	if _, err := io.ReadFull(rnd, buf); err != nil {
+		return err
+	}
+	for i, b := range buf {
+		for b == 0 {
+			if _, err := io.ReadFull(rnd, buf[i:i+1]); err != nil {
+				return err
+			}
+			b = buf[i]
+		}
+		buf[i] = b
+	}
+	return nil
+}
+
+// DecryptPKCS1v15 decrypts ciphertext with PKCS#1 v1.5 padding, checking the
+// padding in constant time.
+func DecryptPKCS1v15(priv *PrivateKey, ciphertext []byte) ([]byte, error) {
+	k := (priv.N.BitLen() + 7) / 8
+	if len(ciphertext) != k || k < 11 {
+		return nil, errors.New("rsa: decryption error")
+	}
+
+	c := new(big.Int).SetBytes(ciphertext)
+	if c.Cmp(priv.N) >= 0 {
+		return nil, errors.New("rsa: decryption error")
+	}
+
+	m := decryptCRT(priv, c)
+	em, err := i2osp(m, k)
+	if err != nil {
+		return nil, errors.New("rsa: decryption error")
+	}
+
+	// Constant-time padding validation: 0x00 0x02 <non-zero padding> 0x00 <msg>
+	valid := subtle.ConstantTimeByteEq(em[0], 0x00)
+	valid &= subtle.ConstantTimeByteEq(em[1], 0x02)
+
+	lookingForIndex := 1
+	index := 0
+	for i := 2; i < len(em); i++ {
+		isZero := subtle.ConstantTimeByteEq(em[i], 0x00)
+		index = subtle.ConstantTimeSelect(lookingForIndex&isZero, i, index)
+		lookingForIndex = subtle.ConstantTimeSelect(isZero, 0, lookingForIndex)
+	}
+	valid &= subtle.ConstantTimeSelect(lookingForIndex, 0, 1)
+	// The padding string PS must be at least 8 bytes (index is where the
+	// 0x00 separator was found, at offset 2+len(PS)).
+	validPS := subtle.ConstantTimeLessOrEq(2+8, index)
+	valid &= validPS
+
+	if valid == 0 {
+		return nil, errors.New("rsa: decryption error")
+	}
+	return em[index+1:], nil
+}
+
+// EncryptOAEP encrypts msg using RSA-OAEP with the given hash function and
+// optional label.
+func EncryptOAEP(h hash.Hash, rnd io.Reader, pub *PublicKey, msg, label []byte) ([]byte, error) {
+	h.Reset()
+	hashLen := h.Size()
+	k := (pub.N.BitLen() + 7) / 8
+
+	if len(msg) > k-2*hashLen-2 {
+		return nil, errors.New("rsa: message too long for modulus")
+	}
+
+	lHash := hashSum(h, label)
+
+	dataBlock := make([]byte, k-hashLen-1)
+	copy(dataBlock, lHash)
+	dataBlock[k-hashLen-1-len(msg)-1] = 0x01
+	copy(dataBlock[k-hashLen-len(msg)-1:], msg)
+
+	seed := make([]byte, hashLen)
+	if _, err := io.ReadFull(rnd, seed); err != nil {
+		return nil, err
+	}
+
+	maskedDB := xorBytes(dataBlock, mgf1(h, seed, len(dataBlock)))
+	maskedSeed := xorBytes(seed, mgf1(h, maskedDB, hashLen))
+
+	em := make([]byte, k)
+	em[0] = 0x00
+	copy(em[1:1+hashLen], maskedSeed)
+	copy(em[1+hashLen:], maskedDB)
+
+	m := new(big.Int).SetBytes(em)
+	c := new(big.Int).Exp(m, big.NewInt(int64(pub.E)), pub.N)
+	return i2osp(c, k)
+}
+
+// DecryptOAEP decrypts ciphertext using RSA-OAEP with the given hash
+// function and label.
+func DecryptOAEP(h hash.Hash, priv *PrivateKey, ciphertext, label []byte) ([]byte, error) {
+	h.Reset()
+	hashLen := h.Size()
+	k := (priv.N.BitLen() + 7) / 8
+
+	if len(ciphertext) != k || k < 2*hashLen+2 {
+		return nil, errors.New("rsa: decryption error")
+	}
+
+	c := new(big.Int).SetBytes(ciphertext)
+	if c.Cmp(priv.N) >= 0 {
+		return nil, errors.New("rsa: decryption error")
+	}
+
+	m := decryptCRT(priv, c)
+	em, err := i2osp(m, k)
+	if err != nil {
+		return nil, errors.New("rsa: decryption error")
+	}
+
+	maskedSeed := em[1 : 1+hashLen]
+	maskedDB := em[1+hashLen:]
+
+	seed := xorBytes(maskedSeed, mgf1(h, maskedDB, hashLen))
+	dataBlock := xorBytes(maskedDB, mgf1(h, seed, len(maskedDB)))
+
+	lHash := hashSum(h, label)
+
+	valid := subtle.ConstantTimeCompare(dataBlock[:hashLen], lHash)
+	valid &= subtle.ConstantTimeByteEq(em[0], 0x00)
+
+	rest := dataBlock[hashLen:]
+	lookingForIndex := 1
+	index := len(rest)
+	for i := 0; i < len(rest); i++ {
+		isZero := subtle.ConstantTimeByteEq(rest[i], 0x00)
+		isOne := subtle.ConstantTimeByteEq(rest[i], 0x01)
+		index = subtle.ConstantTimeSelect(lookingForIndex&isOne, i, index)
+		lookingForIndex = subtle.ConstantTimeSelect(isOne, 0, lookingForIndex)
+		valid &= isZero | isOne | (1 ^ lookingForIndex)
+	}
+	valid &= subtle.ConstantTimeSelect(lookingForIndex, 0, 1)
+
+	if valid == 0 {
+		return nil, errors.New("rsa: decryption error")
+	}
+	return rest[index+1:], nil
+}
+
+func hashSum(h hash.Hash, data []byte) []byte {
+	h.Reset()
+	h.Write(data)
+	sum := h.Sum(nil)
+	h.Reset()
+	return sum
+}
+
+// mgf1 is the MGF1 mask generation function from PKCS#1.
+func mgf1(h hash.Hash, seed []byte, length int) []byte {
+	var out []byte
+	var counter uint32
+	for len(out) < length {
+		h.Reset()
+		h.Write(seed)
+		cBytes := []byte{byte(counter >> 24), byte(counter >> 16), byte(counter >> 8), byte(counter)}
+		h.Write(cBytes)
+		out = append(out, h.Sum(nil)...)
+		counter++
+	}
+	return out[:length]
+}
+
+func xorBytes(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
@@ -0,0 +1,93 @@
+package rsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+)
+
+func mustGenerateKey(t *testing.T, bits int) *PrivateKey {
+	t.Helper()
+	priv, err := GenerateKey(bits, rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(%d) returned error: %v", bits, err)
+	}
+	return priv
+}
+
+func TestPKCS1v15RoundTrip(t *testing.T) {
+	priv := mustGenerateKey(t, 512)
+	msg := []byte("HELLOWORLD")
+
+	ct, err := EncryptPKCS1v15(rand.Reader, &priv.PublicKey, msg)
+	if err != nil {
+		t.Fatalf("EncryptPKCS1v15 returned error: %v", err)
+	}
+	pt, err := DecryptPKCS1v15(priv, ct)
+	if err != nil {
+		t.Fatalf("DecryptPKCS1v15 returned error: %v", err)
+	}
+	if !bytes.Equal(pt, msg) {
+		t.Fatalf("round trip = %q, want %q", pt, msg)
+	}
+}
+
+func TestPKCS1v15DecryptRejectsShortPadding(t *testing.T) {
+	priv := mustGenerateKey(t, 512)
+	k := (priv.N.BitLen() + 7) / 8
+
+	// Build a ciphertext that decrypts to 00 02 <6-byte PS> 00 <msg>, two
+	// bytes short of the mandated 8-byte minimum padding string.
+	em := make([]byte, k)
+	em[0] = 0x00
+	em[1] = 0x02
+	for i := 2; i < 8; i++ {
+		em[i] = 0xff
+	}
+	em[8] = 0x00
+	copy(em[9:], []byte("short"))
+
+	m := new(big.Int).SetBytes(em)
+	c := new(big.Int).Exp(m, big.NewInt(int64(priv.E)), priv.N)
+	ct, err := i2osp(c, k)
+	if err != nil {
+		t.Fatalf("i2osp returned error: %v", err)
+	}
+
+	if _, err := DecryptPKCS1v15(priv, ct); err == nil {
+		t.Fatal("DecryptPKCS1v15 accepted a ciphertext with short padding")
+	}
+}
+
+func TestOAEPRoundTrip(t *testing.T) {
+	priv := mustGenerateKey(t, 1024)
+	msg := []byte("HELLOWORLD")
+	label := []byte("test-label")
+
+	ct, err := EncryptOAEP(sha256.New(), rand.Reader, &priv.PublicKey, msg, label)
+	if err != nil {
+		t.Fatalf("EncryptOAEP returned error: %v", err)
+	}
+	pt, err := DecryptOAEP(sha256.New(), priv, ct, label)
+	if err != nil {
+		t.Fatalf("DecryptOAEP returned error: %v", err)
+	}
+	if !bytes.Equal(pt, msg) {
+		t.Fatalf("round trip = %q, want %q", pt, msg)
+	}
+}
+
+func TestOAEPDecryptRejectsWrongLabel(t *testing.T) {
+	priv := mustGenerateKey(t, 1024)
+	msg := []byte("HELLOWORLD")
+
+	ct, err := EncryptOAEP(sha256.New(), rand.Reader, &priv.PublicKey, msg, []byte("label-a"))
+	if err != nil {
+		t.Fatalf("EncryptOAEP returned error: %v", err)
+	}
+	if _, err := DecryptOAEP(sha256.New(), priv, ct, []byte("label-b")); err == nil {
+		t.Fatal("DecryptOAEP accepted a ciphertext under the wrong label")
+	}
+}
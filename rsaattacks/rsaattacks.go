@@ -0,0 +1,186 @@
+// Package rsaattacks demonstrates classic cryptanalytic attacks against
+// textbook and low-exponent RSA, built on the bigmath and rsa primitives.
+// It exists purely for the educational angle this module is built around:
+// none of this is for attacking real systems, only for showing why RSA
+// needs padding, fresh randomness per message, and exponents chosen with
+// care.
+package rsaattacks
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/GoodbyeKittyy/modular-arithmetic-clock/bigmath"
+)
+
+// HastadBroadcast recovers a plaintext m from e ciphertexts of the same
+// message encrypted under pairwise-coprime moduli with exponent e, by
+// combining the ciphertexts with CRT and taking the integer e-th root.
+func HastadBroadcast(ciphers []*big.Int, moduli []*big.Int, e int) (*big.Int, error) {
+	if len(ciphers) != len(moduli) {
+		return nil, errors.New("rsaattacks: ciphers and moduli must have same length")
+	}
+	if len(ciphers) < e {
+		return nil, errors.New("rsaattacks: need at least e ciphertexts")
+	}
+
+	combined, _, err := bigmath.CRT(ciphers, moduli)
+	if err != nil {
+		return nil, err
+	}
+
+	root, exact := integerNthRoot(combined, e)
+	if !exact {
+		return nil, errors.New("rsaattacks: no exact integer e-th root found")
+	}
+	return root, nil
+}
+
+// integerNthRoot finds the integer n-th root of x via binary search,
+// reporting whether x is exactly a perfect n-th power.
+func integerNthRoot(x *big.Int, n int) (*big.Int, bool) {
+	if x.Sign() == 0 {
+		return big.NewInt(0), true
+	}
+
+	lo := big.NewInt(0)
+	hi := new(big.Int).Add(x, big.NewInt(1))
+	bigN := big.NewInt(int64(n))
+
+	for new(big.Int).Sub(hi, lo).Cmp(big.NewInt(1)) > 0 {
+		mid := new(big.Int).Add(lo, hi)
+		mid.Rsh(mid, 1)
+
+		power := new(big.Int).Exp(mid, bigN, nil)
+		if power.Cmp(x) <= 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	power := new(big.Int).Exp(lo, bigN, nil)
+	return lo, power.Cmp(x) == 0
+}
+
+// CommonModulusAttack recovers the plaintext m from two ciphertexts of the
+// same message under the same modulus n but distinct coprime exponents
+// e1, e2, using the extended Euclidean algorithm to solve a*e1+b*e2=1 and
+// computing m = c1^a * c2^b mod n.
+func CommonModulusAttack(c1, c2 *big.Int, e1, e2 int, n *big.Int) (*big.Int, error) {
+	be1 := big.NewInt(int64(e1))
+	be2 := big.NewInt(int64(e2))
+
+	g, a, b := bigmath.ExtendedGCD(be1, be2)
+	if g.Cmp(big.NewInt(1)) != 0 {
+		return nil, errors.New("rsaattacks: exponents must be coprime")
+	}
+
+	var part1, part2 *big.Int
+
+	if a.Sign() >= 0 {
+		part1 = new(big.Int).Exp(c1, a, n)
+	} else {
+		inv, err := bigmath.ModInverse(c1, n)
+		if err != nil {
+			return nil, err
+		}
+		part1 = new(big.Int).Exp(inv, new(big.Int).Neg(a), n)
+	}
+
+	if b.Sign() >= 0 {
+		part2 = new(big.Int).Exp(c2, b, n)
+	} else {
+		inv, err := bigmath.ModInverse(c2, n)
+		if err != nil {
+			return nil, err
+		}
+		part2 = new(big.Int).Exp(inv, new(big.Int).Neg(b), n)
+	}
+
+	m := new(big.Int).Mul(part1, part2)
+	return m.Mod(m, n), nil
+}
+
+// WienerAttack recovers the private exponent d from a public key (e, n)
+// when d is small, by walking the continued-fraction convergents of e/n
+// and testing each convergent k/d against phi(n) derived from the
+// quadratic x^2 - (n - phi + 1)x + n = 0.
+func WienerAttack(e, n *big.Int) (*big.Int, error) {
+	convergents := continuedFractionConvergents(e, n)
+
+	for _, conv := range convergents {
+		k, d := conv[0], conv[1]
+		if k.Sign() == 0 || d.Sign() == 0 {
+			continue
+		}
+
+		// phi = (e*d - 1) / k must be an exact integer.
+		ed := new(big.Int).Mul(e, d)
+		edMinus1 := new(big.Int).Sub(ed, big.NewInt(1))
+
+		rem := new(big.Int).Mod(edMinus1, k)
+		if rem.Sign() != 0 {
+			continue
+		}
+		phi := new(big.Int).Div(edMinus1, k)
+
+		// Solve x^2 - (n - phi + 1)x + n = 0 for p, q.
+		sumPQ := new(big.Int).Sub(n, phi)
+		sumPQ.Add(sumPQ, big.NewInt(1))
+
+		discriminant := new(big.Int).Mul(sumPQ, sumPQ)
+		discriminant.Sub(discriminant, new(big.Int).Mul(big.NewInt(4), n))
+		if discriminant.Sign() < 0 {
+			continue
+		}
+
+		sqrtDisc, exact := integerNthRoot(discriminant, 2)
+		if !exact {
+			continue
+		}
+
+		p := new(big.Int).Add(sumPQ, sqrtDisc)
+		p.Div(p, big.NewInt(2))
+		q := new(big.Int).Sub(sumPQ, sqrtDisc)
+		q.Div(q, big.NewInt(2))
+
+		if new(big.Int).Mul(p, q).Cmp(n) == 0 {
+			return d, nil
+		}
+	}
+
+	return nil, errors.New("rsaattacks: Wiener attack failed, d is likely not small enough")
+}
+
+// continuedFractionConvergents returns the convergents k_i/d_i of the
+// continued fraction expansion of e/n.
+func continuedFractionConvergents(e, n *big.Int) [][2]*big.Int {
+	a, b := new(big.Int).Set(e), new(big.Int).Set(n)
+
+	var quotients []*big.Int
+	for b.Sign() != 0 {
+		q, r := new(big.Int), new(big.Int)
+		q.DivMod(a, b, r)
+		quotients = append(quotients, q)
+		a, b = b, r
+	}
+
+	var convergents [][2]*big.Int
+	kPrev2, kPrev1 := big.NewInt(0), big.NewInt(1)
+	dPrev2, dPrev1 := big.NewInt(1), big.NewInt(0)
+
+	for _, q := range quotients {
+		k := new(big.Int).Mul(q, kPrev1)
+		k.Add(k, kPrev2)
+		d := new(big.Int).Mul(q, dPrev1)
+		d.Add(d, dPrev2)
+
+		convergents = append(convergents, [2]*big.Int{k, d})
+
+		kPrev2, kPrev1 = kPrev1, k
+		dPrev2, dPrev1 = dPrev1, d
+	}
+
+	return convergents
+}
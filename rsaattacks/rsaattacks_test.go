@@ -0,0 +1,82 @@
+package rsaattacks
+
+import (
+	"math/big"
+	"testing"
+)
+
+func big64(v int64) *big.Int { return big.NewInt(v) }
+
+func TestHastadBroadcast(t *testing.T) {
+	// e=3, same message m=42 broadcast under three pairwise-coprime moduli.
+	const e = 3
+	m := big64(42)
+	moduli := []*big.Int{big64(97), big64(101), big64(103)}
+
+	ciphers := make([]*big.Int, len(moduli))
+	for i, n := range moduli {
+		ciphers[i] = new(big.Int).Exp(m, big64(e), n)
+	}
+
+	recovered, err := HastadBroadcast(ciphers, moduli, e)
+	if err != nil {
+		t.Fatalf("HastadBroadcast returned error: %v", err)
+	}
+	if recovered.Cmp(m) != 0 {
+		t.Errorf("HastadBroadcast = %s, want %s", recovered, m)
+	}
+}
+
+func TestHastadBroadcastNoExactRoot(t *testing.T) {
+	moduli := []*big.Int{big64(97), big64(101), big64(103)}
+	ciphers := []*big.Int{big64(5), big64(7), big64(11)}
+
+	if _, err := HastadBroadcast(ciphers, moduli, 3); err == nil {
+		t.Error("HastadBroadcast should fail when no exact e-th root exists")
+	}
+}
+
+func TestCommonModulusAttack(t *testing.T) {
+	n := big64(3233) // 61 * 53
+	m := big64(65)
+	e1, e2 := 7, 11 // coprime
+
+	c1 := new(big.Int).Exp(m, big64(int64(e1)), n)
+	c2 := new(big.Int).Exp(m, big64(int64(e2)), n)
+
+	recovered, err := CommonModulusAttack(c1, c2, e1, e2, n)
+	if err != nil {
+		t.Fatalf("CommonModulusAttack returned error: %v", err)
+	}
+	if recovered.Cmp(m) != 0 {
+		t.Errorf("CommonModulusAttack = %s, want %s", recovered, m)
+	}
+}
+
+func TestCommonModulusAttackRejectsNonCoprimeExponents(t *testing.T) {
+	n := big64(3233)
+	if _, err := CommonModulusAttack(big64(1), big64(1), 4, 6, n); err == nil {
+		t.Error("CommonModulusAttack should reject non-coprime exponents")
+	}
+}
+
+func TestWienerAttack(t *testing.T) {
+	// Small p, q with a deliberately small private exponent d.
+	p, q := big64(1000003), big64(1000033)
+	n := new(big.Int).Mul(p, q)
+	phi := new(big.Int).Mul(new(big.Int).Sub(p, big64(1)), new(big.Int).Sub(q, big64(1)))
+
+	d := big64(17) // small, vulnerable to Wiener's attack
+	e := new(big.Int).ModInverse(d, phi)
+	if e == nil {
+		t.Fatal("failed to build test key: d has no inverse mod phi")
+	}
+
+	recovered, err := WienerAttack(e, n)
+	if err != nil {
+		t.Fatalf("WienerAttack returned error: %v", err)
+	}
+	if recovered.Cmp(d) != 0 {
+		t.Errorf("WienerAttack = %s, want %s", recovered, d)
+	}
+}